@@ -23,18 +23,42 @@ func (g *Graph) UpsertAddress(ctx context.Context, addr string) (*types.Asset, e
 
 // NameAddrPair represents a relationship between a DNS name and an IP address it eventually resolves to.
 type NameAddrPair struct {
-	FQDN *domain.FQDN
-	Addr *network.IPAddress
+	FQDN  *domain.FQDN
+	Addr  *network.IPAddress
+	Chain []string
+}
+
+// NamesToAddrsOptions controls how NamesToAddrs walks alias chains on its way to an address.
+type NamesToAddrsOptions struct {
+	// MaxDepth bounds how many alias hops are followed before giving up.
+	MaxDepth int
+	// IncludeReverse also walks incoming CNAME/DNAME edges, so a query for an apex
+	// name picks up addresses discovered only through aliases pointing at it.
+	IncludeReverse bool
+	// IncludeDNAME follows dname_record edges (RFC 6672 subtree aliases) in addition to CNAME/SRV.
+	IncludeDNAME bool
+	// IncludeMXAdditional also resolves addresses for the MX targets reached from the queried
+	// name, mirroring how a DNS response's additional section bundles mail exchanger glue records.
+	IncludeMXAdditional bool
+}
+
+// DefaultNamesToAddrsOptions returns sensible defaults: a max depth of 10 alias hops, with
+// DNAME-following enabled and MX-additional resolution left off since it isn't always wanted.
+func DefaultNamesToAddrsOptions() *NamesToAddrsOptions {
+	return &NamesToAddrsOptions{
+		MaxDepth:     10,
+		IncludeDNAME: true,
+	}
 }
 
 // NamesToAddrs returns a NameAddrPair for each name / address combination discovered in the graph.
-func (g *Graph) NamesToAddrs(ctx context.Context, since time.Time, names ...string) ([]*NameAddrPair, error) {
-	nameAddrMap := make(map[string]*stringset.Set, len(names))
-	defer func() {
-		for _, ss := range nameAddrMap {
-			ss.Close()
-		}
-	}()
+func (g *Graph) NamesToAddrs(ctx context.Context, since time.Time, opts *NamesToAddrsOptions, names ...string) ([]*NameAddrPair, error) {
+	if opts == nil {
+		opts = DefaultNamesToAddrsOptions()
+	}
+
+	// name -> address -> the alias chain that produced that address
+	nameAddrMap := make(map[string]map[string][]string, len(names))
 
 	var fqdns []*types.Asset
 	filter := stringset.New()
@@ -55,34 +79,62 @@ func (g *Graph) NamesToAddrs(ctx context.Context, since time.Time, names ...stri
 	type target struct {
 		fqdn  *domain.FQDN
 		asset *types.Asset
+		chain []string
 	}
 	var targets []*target
 	// Obtain the assets that could have address relations
 	for _, a := range fqdns {
 		if fqdn, ok := a.Asset.(domain.FQDN); ok {
 			cur := a
-			// Get to the end of the alias chains for service names and CNAMES
-			for i := 1; i <= 10; i++ {
+			chain := []string{fqdn.Name}
+			visited := stringset.New()
+			visited.Insert(a.ID)
+
+			// Get to the end of the alias chains for service names, CNAMEs and DNAMEs
+			for i := 1; i <= opts.MaxDepth; i++ {
 				reltypes := []string{"cname_record"}
 				if i == 1 {
 					reltypes = append(reltypes, "srv_record")
 				}
+				if opts.IncludeDNAME {
+					reltypes = append(reltypes, "dname_record")
+				}
 
-				if rels, err := g.DB.OutgoingRelations(cur, since, reltypes...); err == nil && len(rels) > 0 {
-					for _, rel := range rels {
-						if found, err := g.DB.FindById(rel.ToAsset.ID, since); err == nil {
-							cur = found
-							break
-						}
+				rels, err := g.DB.OutgoingRelations(cur, since, reltypes...)
+				if err != nil || len(rels) == 0 {
+					break
+				}
+
+				advanced := false
+				for _, rel := range rels {
+					if visited.Has(rel.ToAsset.ID) {
+						// following this edge would re-enter the chain, so stop here
+						continue
+					}
+
+					found, err := g.DB.FindById(rel.ToAsset.ID, since)
+					if err != nil {
+						continue
+					}
+
+					cur = found
+					visited.Insert(rel.ToAsset.ID)
+					if fq, ok := found.Asset.(domain.FQDN); ok {
+						chain = append(chain, fq.Name)
 					}
-				} else {
+					advanced = true
+					break
+				}
+				if !advanced {
 					break
 				}
 			}
+			visited.Close()
 
 			targets = append(targets, &target{
 				fqdn:  &fqdn,
 				asset: cur,
+				chain: chain,
 			})
 		}
 	}
@@ -92,22 +144,43 @@ func (g *Graph) NamesToAddrs(ctx context.Context, since time.Time, names ...stri
 	}
 
 	for _, tar := range targets {
-		if rels, err := g.DB.OutgoingRelations(tar.asset, since, "a_record", "aaaa_record"); err == nil && len(rels) > 0 {
-			name := tar.fqdn.Name
+		name := tar.fqdn.Name
 
-			for _, rel := range rels {
-				if _, found := nameAddrMap[name]; !found {
-					nameAddrMap[name] = stringset.New()
+		g.collectAddrs(tar.asset, since, name, tar.chain, nameAddrMap)
+
+		if opts.IncludeMXAdditional {
+			if rels, err := g.DB.OutgoingRelations(tar.asset, since, "mx_record"); err == nil {
+				for _, rel := range rels {
+					mx, err := g.DB.FindById(rel.ToAsset.ID, since)
+					if err != nil {
+						continue
+					}
+
+					mxChain := append(append([]string{}, tar.chain...), "MX:"+aliasName(mx))
+					g.collectAddrs(mx, since, name, mxChain, nameAddrMap)
 				}
+			}
+		}
 
-				found, err := g.DB.FindById(rel.ToAsset.ID, since)
+		if !opts.IncludeReverse {
+			continue
+		}
+		// Walk incoming CNAME/DNAME edges so aliases pointing at this name also
+		// contribute any addresses attached directly to them, tagged with the chain
+		// that actually produced them (the apex's chain plus the alias that points at it).
+		reltypes := []string{"cname_record"}
+		if opts.IncludeDNAME {
+			reltypes = append(reltypes, "dname_record")
+		}
+		if rels, err := g.DB.IncomingRelations(tar.asset, since, reltypes...); err == nil {
+			for _, rel := range rels {
+				alias, err := g.DB.FindById(rel.FromAsset.ID, since)
 				if err != nil {
 					continue
 				}
 
-				if a, ok := found.Asset.(network.IPAddress); ok {
-					nameAddrMap[name].Insert(a.Address.String())
-				}
+				aliasChain := append(append([]string{}, tar.chain...), aliasName(alias))
+				g.collectAddrs(alias, since, name, aliasChain, nameAddrMap)
 			}
 		}
 	}
@@ -123,11 +196,45 @@ func (g *Graph) NamesToAddrs(ctx context.Context, since time.Time, names ...stri
 	return pairs, nil
 }
 
-func generatePairsFromAddrMap(addrMap map[string]*stringset.Set) []*NameAddrPair {
+func aliasName(asset *types.Asset) string {
+	if fq, ok := asset.Asset.(domain.FQDN); ok {
+		return fq.Name
+	}
+	return ""
+}
+
+// collectAddrs adds every address reachable from asset via outgoing A/AAAA edges to
+// nameAddrMap[name], tagging each with the alias chain that led to it.
+func (g *Graph) collectAddrs(asset *types.Asset, since time.Time, name string, chain []string, nameAddrMap map[string]map[string][]string) {
+	rels, err := g.DB.OutgoingRelations(asset, since, "a_record", "aaaa_record")
+	if err != nil || len(rels) == 0 {
+		return
+	}
+
+	if _, found := nameAddrMap[name]; !found {
+		nameAddrMap[name] = make(map[string][]string)
+	}
+
+	for _, rel := range rels {
+		found, err := g.DB.FindById(rel.ToAsset.ID, since)
+		if err != nil {
+			continue
+		}
+
+		if a, ok := found.Asset.(network.IPAddress); ok {
+			addr := a.Address.String()
+			if _, found := nameAddrMap[name][addr]; !found {
+				nameAddrMap[name][addr] = chain
+			}
+		}
+	}
+}
+
+func generatePairsFromAddrMap(addrMap map[string]map[string][]string) []*NameAddrPair {
 	var pairs []*NameAddrPair
 
-	for name, set := range addrMap {
-		for _, addr := range set.Slice() {
+	for name, addrs := range addrMap {
+		for addr, chain := range addrs {
 			if ip, err := netip.ParseAddr(addr); err == nil {
 				address := &network.IPAddress{Address: ip}
 				if ip.Is4() {
@@ -136,8 +243,9 @@ func generatePairsFromAddrMap(addrMap map[string]*stringset.Set) []*NameAddrPair
 					address.Type = "IPv6"
 				}
 				pairs = append(pairs, &NameAddrPair{
-					FQDN: &domain.FQDN{Name: name},
-					Addr: address,
+					FQDN:  &domain.FQDN{Name: name},
+					Addr:  address,
+					Chain: chain,
 				})
 			}
 		}
@@ -145,17 +253,61 @@ func generatePairsFromAddrMap(addrMap map[string]*stringset.Set) []*NameAddrPair
 	return pairs
 }
 
-// UpsertA creates FQDN, IP address and A record edge in the graph and associates them with a source and event.
-func (g *Graph) UpsertA(ctx context.Context, fqdn, addr string) (*types.Asset, error) {
-	return g.addrRecord(ctx, fqdn, addr, "a_record")
+// UpsertPTR creates an IP address, FQDN and PTR record edge in the graph and associates them
+// with a source and event. observed is an optional (firstSeen, lastSeen) pair — when a caller
+// has its own observation window for the record (e.g. a passive-DNS provider), passing both
+// timestamps here persists them as metadata on the created edge; omit it to have the edge
+// timestamped with the asset-db's own bookkeeping.
+func (g *Graph) UpsertPTR(ctx context.Context, addr, ptr string, observed ...time.Time) (*types.Asset, error) {
+	ip := buildIPAddress(addr)
+	if ip == nil {
+		return nil, errors.New("failed to build the OAM IPAddress")
+	}
+
+	a, err := g.DB.Create(nil, "", ip)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := g.DB.Create(a, "ptr_record", &domain.FQDN{Name: ptr})
+	if err != nil {
+		return nil, err
+	}
+
+	g.tagObserved(time.Time{}, a, "ptr_record", target, observed)
+	return target, nil
+}
+
+// UpsertCNAME creates FQDN, alias FQDN and CNAME record edge in the graph and associates them
+// with a source and event. See UpsertPTR for the meaning of observed.
+func (g *Graph) UpsertCNAME(ctx context.Context, fqdn, target string, observed ...time.Time) (*types.Asset, error) {
+	name, err := g.UpsertFQDN(ctx, fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	tar, err := g.DB.Create(name, "cname_record", &domain.FQDN{Name: target})
+	if err != nil {
+		return nil, err
+	}
+
+	g.tagObserved(time.Time{}, name, "cname_record", tar, observed)
+	return tar, nil
+}
+
+// UpsertA creates FQDN, IP address and A record edge in the graph and associates them with a
+// source and event. See UpsertPTR for the meaning of observed.
+func (g *Graph) UpsertA(ctx context.Context, fqdn, addr string, observed ...time.Time) (*types.Asset, error) {
+	return g.addrRecord(ctx, fqdn, addr, "a_record", observed...)
 }
 
-// UpsertAAAA creates FQDN, IP address and AAAA record edge in the graph and associates them with a source and event.
-func (g *Graph) UpsertAAAA(ctx context.Context, fqdn, addr string) (*types.Asset, error) {
-	return g.addrRecord(ctx, fqdn, addr, "aaaa_record")
+// UpsertAAAA creates FQDN, IP address and AAAA record edge in the graph and associates them with
+// a source and event. See UpsertPTR for the meaning of observed.
+func (g *Graph) UpsertAAAA(ctx context.Context, fqdn, addr string, observed ...time.Time) (*types.Asset, error) {
+	return g.addrRecord(ctx, fqdn, addr, "aaaa_record", observed...)
 }
 
-func (g *Graph) addrRecord(ctx context.Context, fqdn, addr, rrtype string) (*types.Asset, error) {
+func (g *Graph) addrRecord(ctx context.Context, fqdn, addr, rrtype string, observed ...time.Time) (*types.Asset, error) {
 	name, err := g.UpsertFQDN(ctx, fqdn)
 	if err != nil {
 		return nil, err
@@ -166,7 +318,45 @@ func (g *Graph) addrRecord(ctx context.Context, fqdn, addr, rrtype string) (*typ
 		return nil, errors.New("failed to build the OAM IPAddress")
 	}
 
-	return g.DB.Create(name, rrtype, ip)
+	target, err := g.DB.Create(name, rrtype, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	g.tagObserved(time.Time{}, name, rrtype, target, observed)
+	return target, nil
+}
+
+// tagObserved persists an explicit (firstSeen, lastSeen) observation window as metadata on the
+// edge from source to target, when the caller supplied one. It is a best-effort call: a data
+// source that can't report its own observation window (most can't) simply leaves the edge
+// timestamped by the asset-db's own bookkeeping, and a failure to tag is not treated as fatal
+// since the edge itself was already created successfully.
+func (g *Graph) tagObserved(since time.Time, source *types.Asset, relation string, target *types.Asset, observed []time.Time) {
+	if len(observed) != 2 || source == nil || target == nil {
+		return
+	}
+	firstSeen, lastSeen := observed[0], observed[1]
+	if firstSeen.IsZero() && lastSeen.IsZero() {
+		return
+	}
+
+	rels, err := g.DB.OutgoingRelations(source, since, relation)
+	if err != nil {
+		return
+	}
+	for _, rel := range rels {
+		if rel.ToAsset.ID != target.ID {
+			continue
+		}
+		if !firstSeen.IsZero() {
+			_ = g.DB.CreateEdgeProperty(rel, "first_seen", firstSeen.UTC().Format(time.RFC3339))
+		}
+		if !lastSeen.IsZero() {
+			_ = g.DB.CreateEdgeProperty(rel, "last_seen", lastSeen.UTC().Format(time.RFC3339))
+		}
+		return
+	}
 }
 
 func buildIPAddress(addr string) *network.IPAddress {
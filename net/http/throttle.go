@@ -0,0 +1,159 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Policy configures how RequestWebPageThrottled paces and caches requests made on behalf of a plugin.
+type Policy struct {
+	// RPS is the maximum number of requests per second allowed for requests sharing the same key.
+	// Limiting is per key, not per host: requests to one host made under different keys are limited separately.
+	RPS float64
+	// Burst is the number of requests that can be made in a single burst.
+	Burst int
+	// CacheTTL is how long a successful GET response is reused from the on-disk cache. Zero disables caching.
+	CacheTTL time.Duration
+	// MaxRetries bounds the number of retries performed after a failed request or a 429 response.
+	MaxRetries int
+}
+
+// DefaultPolicy is used when a plugin does not need anything more aggressive than polite defaults.
+func DefaultPolicy() *Policy {
+	return &Policy{
+		RPS:        1,
+		Burst:      2,
+		CacheTTL:   24 * time.Hour,
+		MaxRetries: 3,
+	}
+}
+
+var (
+	limiterLock sync.Mutex
+	limiters    = make(map[string]*rate.Limiter)
+)
+
+func limiterFor(key string, policy *Policy) *rate.Limiter {
+	limiterLock.Lock()
+	defer limiterLock.Unlock()
+
+	l, found := limiters[key]
+	if !found {
+		l = rate.NewLimiter(rate.Limit(policy.RPS), policy.Burst)
+		limiters[key] = l
+	}
+	return l
+}
+
+// RequestWebPageThrottled behaves like RequestWebPage, but rate limits requests sharing the
+// same key, retries failed requests and 429 responses with exponential backoff (a numeric
+// Retry-After header sets the wait instead), and caches 200 responses on disk for
+// policy.CacheTTL, whatever the request method, keyed by key, URL and sessionID, so repeated runs against
+// the same target within one session don't re-hammer the source, and separate sessions never
+// share cached results. The provided ctx is honored for cancellation/timeouts.
+func RequestWebPageThrottled(ctx context.Context, key, sessionID string, req *Request, policy *Policy) (*Response, error) {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+
+	if policy.CacheTTL > 0 {
+		if resp, ok := readCache(key, sessionID, req.URL, policy.CacheTTL); ok {
+			return resp, nil
+		}
+	}
+
+	limiter := limiterFor(key, policy)
+
+	var resp *Response
+	var err error
+	backoff := time.Second
+	for attempt := 0; attempt <= policy.MaxRetries; attempt++ {
+		if werr := limiter.Wait(ctx); werr != nil {
+			return nil, werr
+		}
+
+		resp, err = RequestWebPage(ctx, req)
+		if err == nil && (resp == nil || resp.StatusCode != 429) {
+			break
+		}
+
+		wait := backoff
+		if resp != nil {
+			if ra := resp.Header.Get("Retry-After"); ra != "" {
+				if secs, perr := strconv.Atoi(ra); perr == nil {
+					wait = time.Duration(secs) * time.Second
+				}
+			}
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		backoff *= 2
+	}
+	if err != nil {
+		return nil, err
+	}
+	if resp != nil && resp.StatusCode == 429 {
+		return nil, fmt.Errorf("rate limited after %d attempts", policy.MaxRetries+1)
+	}
+
+	if policy.CacheTTL > 0 && resp != nil {
+		writeCache(key, sessionID, req.URL, resp)
+	}
+	return resp, nil
+}
+
+func cachePath(key, sessionID, url string) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(key + "|" + sessionID + "|" + url))
+	return filepath.Join(dir, "amass", "httpcache", hex.EncodeToString(sum[:])), nil
+}
+
+func readCache(key, sessionID, url string, ttl time.Duration) (*Response, bool) {
+	path, err := cachePath(key, sessionID, url)
+	if err != nil {
+		return nil, false
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || time.Since(info.ModTime()) > ttl {
+		return nil, false
+	}
+
+	body, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return &Response{Body: string(body), StatusCode: 200}, true
+}
+
+func writeCache(key, sessionID, url string, resp *Response) {
+	path, err := cachePath(key, sessionID, url)
+	if err != nil || resp.StatusCode != 200 {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(resp.Body), 0o644)
+}
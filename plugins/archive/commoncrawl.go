@@ -0,0 +1,179 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package archive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/caffix/stringset"
+	"github.com/owasp-amass/engine/net/dns"
+	"github.com/owasp-amass/engine/net/http"
+	et "github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/domain"
+)
+
+const (
+	// defaultCCIndexes is the number of most recent CommonCrawl indexes queried per domain in passive mode.
+	defaultCCIndexes = 3
+	// activeCCIndexes is the number of most recent CommonCrawl indexes queried per domain when the session is active.
+	activeCCIndexes = 10
+)
+
+type commonCrawlIndex struct {
+	ID  string `json:"id"`
+	API string `json:"cdx-api"`
+}
+
+type commonCrawl struct {
+	collinfo   string
+	numIndexes int
+	policy     *http.Policy
+}
+
+func NewCommonCrawl() et.Plugin {
+	return &commonCrawl{
+		collinfo:   "https://index.commoncrawl.org/collinfo.json",
+		numIndexes: defaultCCIndexes,
+		policy:     http.DefaultPolicy(),
+	}
+}
+
+func (cc *commonCrawl) Start(r et.Registry) error {
+	name := "CommonCrawl-Handler"
+	if err := r.RegisterHandler(&et.Handler{
+		Name:       name,
+		Transforms: []string{"fqdn"},
+		EventType:  oam.FQDN,
+		Callback:   cc.check,
+	}); err != nil {
+		r.Log().Printf("Failed to register the %s: %v", name, err)
+		return err
+	}
+	return nil
+}
+
+func (cc *commonCrawl) Stop() {}
+
+func (cc *commonCrawl) check(e *et.Event) error {
+	fqdn, ok := e.Asset.Asset.(*domain.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	domlt := strings.ToLower(strings.TrimSpace(fqdn.Name))
+	if e.Session.Config().WhichDomain(domlt) != domlt {
+		return nil
+	}
+
+	matches, err := e.Session.Config().CheckTransformations("fqdn", "fqdn", "commoncrawl")
+	if err != nil {
+		return err
+	}
+	if !matches.IsMatch("fqdn") {
+		return nil
+	}
+
+	numIndexes := cc.numIndexes
+	if e.Session.Config().Active {
+		numIndexes = activeCCIndexes
+	}
+
+	indexes, err := cc.indexes(e, numIndexes)
+	if err != nil {
+		return err
+	}
+
+	subdomains := stringset.New()
+	defer subdomains.Close()
+
+	for _, idx := range indexes {
+		for _, sub := range cc.query(e, idx, domlt) {
+			subdomains.Insert(sub)
+		}
+	}
+
+	cc.submit(e, subdomains.Slice())
+	return nil
+}
+
+// indexes returns, most recent first, up to n CommonCrawl collection identifiers to query.
+func (cc *commonCrawl) indexes(e *et.Event, n int) ([]*commonCrawlIndex, error) {
+	resp, err := http.RequestWebPageThrottled(e.Session.Ctx(), "commoncrawl", e.Session.ID(), &http.Request{URL: cc.collinfo}, cc.policy)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching URL: %w", err)
+	}
+
+	var collections []*commonCrawlIndex
+	if err := json.Unmarshal([]byte(resp.Body), &collections); err != nil {
+		return nil, err
+	}
+
+	if len(collections) > n {
+		collections = collections[:n]
+	}
+	return collections, nil
+}
+
+// query pages through a single CommonCrawl index and returns the subdomains discovered.
+func (cc *commonCrawl) query(e *et.Event, idx *commonCrawlIndex, domlt string) []string {
+	subdomains := stringset.New()
+	defer subdomains.Close()
+
+	numPages := cc.numPages(e, idx, domlt)
+	for page := 0; page < numPages; page++ {
+		url := fmt.Sprintf("%s?url=*.%s&output=json&page=%d", idx.API, domlt, page)
+
+		resp, err := http.RequestWebPageThrottled(e.Session.Ctx(), "commoncrawl", e.Session.ID(), &http.Request{URL: url}, cc.policy)
+		if err != nil {
+			break
+		}
+
+		for _, line := range strings.Split(resp.Body, "\n") {
+			if n := dns.AnySubdomainRegex().FindString(line); n != "" {
+				subdomains.Insert(n)
+			}
+		}
+	}
+	return subdomains.Slice()
+}
+
+// numPages issues the showNumPages probe (no page param, so CommonCrawl returns only
+// the {"pages":N,...} metadata blob instead of CDX rows) to learn how many data pages exist.
+func (cc *commonCrawl) numPages(e *et.Event, idx *commonCrawlIndex, domlt string) int {
+	url := fmt.Sprintf("%s?url=*.%s&output=json&showNumPages=true", idx.API, domlt)
+
+	resp, err := http.RequestWebPageThrottled(e.Session.Ctx(), "commoncrawl", e.Session.ID(), &http.Request{URL: url}, cc.policy)
+	if err != nil {
+		return 1
+	}
+
+	var info struct {
+		Pages int `json:"pages"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &info); err != nil || info.Pages < 1 {
+		return 1
+	}
+	return info.Pages
+}
+
+func (cc *commonCrawl) submit(e *et.Event, subs []string) {
+	for _, n := range subs {
+		// if the subdomain is not in scope, skip it
+		if !e.Session.Config().IsDomainInScope(n) {
+			continue
+		}
+		if a, err := e.Session.DB().Create(nil, "", &domain.FQDN{Name: n}); err == nil && a != nil {
+			_ = e.Dispatcher.DispatchEvent(&et.Event{
+				Name:    n,
+				Asset:   a,
+				Session: e.Session,
+			})
+		}
+	}
+}
@@ -5,7 +5,6 @@
 package archive
 
 import (
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -20,12 +19,14 @@ import (
 )
 
 type wayback struct {
-	URL string
+	URL    string
+	policy *http.Policy
 }
 
 func NewWayback() et.Plugin {
 	return &wayback{
-		URL: "https://web.archive.org/cdx/search/cdx?matchType=domain&fl=original&output=json&collapse=urlkey&url=",
+		URL:    "https://web.archive.org/cdx/search/cdx?matchType=domain&fl=original&output=json&collapse=urlkey&url=",
+		policy: http.DefaultPolicy(),
 	}
 }
 
@@ -64,7 +65,7 @@ func (w *wayback) check(e *et.Event) error {
 		return nil
 	}
 
-	records, err := w.query(domlt)
+	records, err := w.query(e, domlt)
 	if err != nil {
 		return err
 	}
@@ -73,8 +74,8 @@ func (w *wayback) check(e *et.Event) error {
 	return nil
 }
 
-func (w *wayback) query(name string) (string, error) {
-	resp, err := http.RequestWebPage(context.TODO(), &http.Request{URL: w.URL + name})
+func (w *wayback) query(e *et.Event, name string) (string, error) {
+	resp, err := http.RequestWebPageThrottled(e.Session.Ctx(), "wayback", e.Session.ID(), &http.Request{URL: w.URL + name}, w.policy)
 	if err != nil {
 		return "", fmt.Errorf("error fetching URL: %w", err)
 	}
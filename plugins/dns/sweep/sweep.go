@@ -0,0 +1,203 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package sweep
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+
+	"github.com/owasp-amass/engine/graph"
+	"github.com/owasp-amass/engine/plugins/support"
+	et "github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/network"
+	boom "github.com/tylertreat/BoomFilters"
+)
+
+const (
+	// defaultSweepSize is the number of neighboring addresses swept in passive mode.
+	defaultSweepSize = 250
+	// activeSweepSize is the number of neighboring addresses swept when the session is active.
+	activeSweepSize = 500
+	// sweepFilterCells bounds the stable Bloom filter used to skip already-swept neighborhoods.
+	sweepFilterCells = 1_000_000
+	// sweepFilterFPRate is the target false positive rate for the stable Bloom filter.
+	sweepFilterFPRate = 0.01
+	// sweepConcurrency bounds how many PTR lookups are in flight at once per event.
+	sweepConcurrency = 20
+)
+
+type sweep struct {
+	name   string
+	lock   sync.Mutex
+	filter *boom.StableBloomFilter
+}
+
+// NewSweep returns a plugin that performs a reverse-DNS sweep of the netblock
+// surrounding each newly discovered IPv4 address, a technique used by legacy Amass.
+// IPv6 addresses are not swept.
+func NewSweep() et.Plugin {
+	return &sweep{
+		name:   "DNS-Sweep",
+		filter: boom.NewDefaultStableBloomFilter(sweepFilterCells, sweepFilterFPRate),
+	}
+}
+
+func (s *sweep) Start(r et.Registry) error {
+	name := "DNS-Sweep-Handler"
+
+	if err := r.RegisterHandler(&et.Handler{
+		Name:       name,
+		Transforms: []string{"fqdn"},
+		EventType:  oam.IPAddress,
+		Callback:   s.check,
+	}); err != nil {
+		r.Log().Printf("Failed to register the %s: %v", name, err)
+		return err
+	}
+	return nil
+}
+
+func (s *sweep) Stop() {}
+
+func (s *sweep) check(e *et.Event) error {
+	addr, ok := e.Asset.Asset.(*network.IPAddress)
+	if !ok {
+		return errors.New("failed to extract the IPAddress asset")
+	}
+
+	matches, err := e.Session.Config().CheckTransformations("ipaddress", "fqdn", "sweep")
+	if err != nil {
+		return err
+	}
+	if !matches.IsMatch("fqdn") {
+		return nil
+	}
+
+	cidr, err := support.NetblockByAddr(e.Session, addr.Address)
+	if err != nil {
+		// no known enclosing prefix to sweep against
+		return nil
+	}
+
+	size := defaultSweepSize
+	if e.Session.Config().Active {
+		size = activeSweepSize
+	}
+
+	type hit struct {
+		addr  string
+		names []string
+	}
+
+	sem := make(chan struct{}, sweepConcurrency)
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var hits []hit
+
+	for _, target := range s.neighbors(addr.Address, cidr, size) {
+		if s.alreadySwept(cidr, target) {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(target netip.Addr) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			names, err := net.DefaultResolver.LookupAddr(e.Session.Ctx(), target.String())
+			if err != nil {
+				return
+			}
+
+			lock.Lock()
+			hits = append(hits, hit{addr: target.String(), names: names})
+			lock.Unlock()
+		}(target)
+	}
+	wg.Wait()
+
+	g := &graph.Graph{DB: e.Session.DB()}
+	for _, h := range hits {
+		s.submit(e, g, h.addr, h.names)
+	}
+	return nil
+}
+
+// alreadySwept reports whether the cidr|address pair has been seen before, inserting
+// it into the filter when it has not so repeat events for the same neighborhood are cheap.
+func (s *sweep) alreadySwept(cidr netip.Prefix, addr netip.Addr) bool {
+	key := []byte(cidr.String() + "|" + addr.String())
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.filter.TestAndAdd(key)
+}
+
+// neighbors returns up to size addresses within cidr, centered on addr. It returns nil
+// when addr or cidr is IPv6.
+func (s *sweep) neighbors(addr netip.Addr, cidr netip.Prefix, size int) []netip.Addr {
+	if !addr.Is4() || !cidr.Addr().Is4() {
+		return nil
+	}
+
+	base := cidr.Masked().Addr().As4()
+	start := uint32(base[0])<<24 | uint32(base[1])<<16 | uint32(base[2])<<8 | uint32(base[3])
+	numAddrs := uint32(1) << uint(32-cidr.Bits())
+
+	seed4 := addr.As4()
+	seed := uint32(seed4[0])<<24 | uint32(seed4[1])<<16 | uint32(seed4[2])<<8 | uint32(seed4[3])
+	offset := seed - start
+
+	half := uint32(size / 2)
+	var addrs []netip.Addr
+	for i := int64(offset) - int64(half); len(addrs) < size && i <= int64(offset)+int64(half); i++ {
+		if i < 0 || uint32(i) >= numAddrs {
+			continue
+		}
+
+		n := start + uint32(i)
+		ip := netip.AddrFrom4([4]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)})
+		if ip == addr {
+			continue
+		}
+		addrs = append(addrs, ip)
+	}
+	return addrs
+}
+
+func (s *sweep) submit(e *et.Event, g *graph.Graph, addr string, names []string) {
+	for _, name := range names {
+		n := trimFQDN(name)
+		if n == "" || !e.Session.Config().IsDomainInScope(n) {
+			continue
+		}
+
+		if _, err := g.UpsertPTR(context.TODO(), addr, n); err != nil {
+			continue
+		}
+		a, err := g.UpsertA(context.TODO(), n, addr)
+		if err != nil {
+			continue
+		}
+
+		_ = e.Dispatcher.DispatchEvent(&et.Event{
+			Name:    n,
+			Asset:   a,
+			Session: e.Session,
+		})
+	}
+}
+
+func trimFQDN(name string) string {
+	if l := len(name); l > 0 && name[l-1] == '.' {
+		return name[:l-1]
+	}
+	return name
+}
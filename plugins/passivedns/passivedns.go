@@ -0,0 +1,158 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package passivedns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/owasp-amass/asset-db/types"
+	"github.com/owasp-amass/engine/graph"
+	et "github.com/owasp-amass/engine/types"
+	oam "github.com/owasp-amass/open-asset-model"
+	"github.com/owasp-amass/open-asset-model/domain"
+	"github.com/owasp-amass/open-asset-model/network"
+)
+
+type passiveDNS struct {
+	providers []Provider
+}
+
+// NewPassiveDNS returns a plugin that fans a FQDN or IP address out to the enabled
+// passive-DNS providers and feeds every normalized record back into the graph.
+func NewPassiveDNS() et.Plugin {
+	return &passiveDNS{providers: defaultProviders()}
+}
+
+func (pd *passiveDNS) Start(r et.Registry) error {
+	if err := r.RegisterHandler(&et.Handler{
+		Name:       "PassiveDNS-FQDN-Handler",
+		Transforms: []string{"fqdn", "ipaddress"},
+		EventType:  oam.FQDN,
+		Callback:   pd.checkFQDN,
+	}); err != nil {
+		r.Log().Printf("Failed to register the PassiveDNS-FQDN-Handler: %v", err)
+		return err
+	}
+
+	if err := r.RegisterHandler(&et.Handler{
+		Name:       "PassiveDNS-Addr-Handler",
+		Transforms: []string{"fqdn"},
+		EventType:  oam.IPAddress,
+		Callback:   pd.checkAddr,
+	}); err != nil {
+		r.Log().Printf("Failed to register the PassiveDNS-Addr-Handler: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (pd *passiveDNS) Stop() {}
+
+func (pd *passiveDNS) checkFQDN(e *et.Event) error {
+	fqdn, ok := e.Asset.Asset.(*domain.FQDN)
+	if !ok {
+		return errors.New("failed to extract the FQDN asset")
+	}
+
+	domlt := strings.ToLower(strings.TrimSpace(fqdn.Name))
+	if e.Session.Config().WhichDomain(domlt) != domlt {
+		return nil
+	}
+	return pd.query(e, "fqdn", domlt)
+}
+
+func (pd *passiveDNS) checkAddr(e *et.Event) error {
+	addr, ok := e.Asset.Asset.(*network.IPAddress)
+	if !ok {
+		return errors.New("failed to extract the IPAddress asset")
+	}
+	return pd.query(e, "ipaddress", addr.Address.String())
+}
+
+// query fans target out to every enabled provider, dedupes the results and upserts them into the
+// graph. fromType is the OAM type of the asset that triggered the query ("fqdn" or "ipaddress")
+// and gates the transformation the same way every other from-type-aware plugin does.
+func (pd *passiveDNS) query(e *et.Event, fromType, target string) error {
+	matches, err := e.Session.Config().CheckTransformations(fromType, "fqdn", "passivedns")
+	if err != nil {
+		return err
+	}
+	if !matches.IsMatch("fqdn") {
+		return nil
+	}
+
+	var wg sync.WaitGroup
+	var lock sync.Mutex
+	var all []*Record
+
+	for _, p := range pd.providers {
+		cfg := e.Session.Config().GetAPIKey(p.Name())
+		if !cfg.Enabled {
+			continue
+		}
+
+		wg.Add(1)
+		go func(p Provider, apiKey string) {
+			defer wg.Done()
+
+			recs, err := p.Query(e.Session.Ctx(), apiKey, e.Session.ID(), target)
+			if err != nil {
+				return
+			}
+
+			lock.Lock()
+			all = append(all, recs...)
+			lock.Unlock()
+		}(p, cfg.Key)
+	}
+	wg.Wait()
+
+	pd.submit(e, dedupe(all))
+	return nil
+}
+
+func (pd *passiveDNS) submit(e *et.Event, records []*Record) {
+	g := &graph.Graph{DB: e.Session.DB()}
+
+	for _, rec := range records {
+		name := strings.ToLower(strings.TrimSpace(rec.QName))
+		if name == "" || !e.Session.Config().IsDomainInScope(name) {
+			continue
+		}
+
+		var observed []time.Time
+		if !rec.FirstSeen.IsZero() || !rec.LastSeen.IsZero() {
+			observed = []time.Time{rec.FirstSeen, rec.LastSeen}
+		}
+
+		var a *types.Asset
+		var err error
+		switch strings.ToUpper(rec.QType) {
+		case "A":
+			a, err = g.UpsertA(context.TODO(), name, rec.RData, observed...)
+		case "AAAA":
+			a, err = g.UpsertAAAA(context.TODO(), name, rec.RData, observed...)
+		case "CNAME":
+			a, err = g.UpsertCNAME(context.TODO(), name, rec.RData, observed...)
+		case "PTR":
+			a, err = g.UpsertPTR(context.TODO(), rec.RData, name, observed...)
+		default:
+			continue
+		}
+		if err != nil || a == nil {
+			continue
+		}
+
+		_ = e.Dispatcher.DispatchEvent(&et.Event{
+			Name:    name,
+			Asset:   a,
+			Session: e.Session,
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package passivedns
+
+import (
+	"context"
+	"time"
+)
+
+// Record is a normalized passive-DNS observation, regardless of which provider produced it.
+type Record struct {
+	QName     string
+	QType     string
+	RData     string
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Provider is implemented by each passive-DNS data source aggregated by this plugin.
+type Provider interface {
+	// Name identifies the provider for API-key lookup and keys its request rate limiting and response cache.
+	Name() string
+	// Query returns every record the provider has observed for the given FQDN or IP address.
+	Query(ctx context.Context, apiKey, sessionID, target string) ([]*Record, error)
+}
+
+func dedupe(records []*Record) []*Record {
+	seen := make(map[string]*Record, len(records))
+
+	for _, rec := range records {
+		key := rec.QName + "|" + rec.QType + "|" + rec.RData
+		if cur, found := seen[key]; found {
+			if rec.FirstSeen.Before(cur.FirstSeen) {
+				cur.FirstSeen = rec.FirstSeen
+			}
+			if rec.LastSeen.After(cur.LastSeen) {
+				cur.LastSeen = rec.LastSeen
+			}
+			continue
+		}
+		seen[key] = rec
+	}
+
+	out := make([]*Record, 0, len(seen))
+	for _, rec := range seen {
+		out = append(out, rec)
+	}
+	return out
+}
@@ -0,0 +1,154 @@
+// Copyright © by Jeff Foley 2023. All rights reserved.
+// Use of this source code is governed by Apache 2 LICENSE that can be found in the LICENSE file.
+// SPDX-License-Identifier: Apache-2.0
+
+package passivedns
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/owasp-amass/engine/net/http"
+)
+
+// defaultProviders returns the passive-DNS sources this plugin knows how to query.
+func defaultProviders() []Provider {
+	return []Provider{
+		&mnemonic{},
+		&circl{},
+		&otx{},
+		&hackerTarget{},
+	}
+}
+
+type mnemonic struct{}
+
+func (m *mnemonic) Name() string { return "mnemonic" }
+
+func (m *mnemonic) Query(ctx context.Context, apiKey, sessionID, target string) ([]*Record, error) {
+	url := fmt.Sprintf("https://api.mnemonic.no/pdns/v3/%s", target)
+
+	resp, err := http.RequestWebPageThrottled(ctx, m.Name(), sessionID, &http.Request{URL: url}, http.DefaultPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Data []struct {
+			Query        string `json:"query"`
+			RRType       string `json:"rrtype"`
+			Answer       string `json:"answer"`
+			LastSeenTime int64  `json:"lastSeenTimestamp"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	for _, d := range result.Data {
+		records = append(records, &Record{
+			QName:    d.Query,
+			QType:    d.RRType,
+			RData:    d.Answer,
+			LastSeen: time.UnixMilli(d.LastSeenTime),
+		})
+	}
+	return records, nil
+}
+
+type circl struct{}
+
+func (c *circl) Name() string { return "circl" }
+
+func (c *circl) Query(ctx context.Context, apiKey, sessionID, target string) ([]*Record, error) {
+	url := fmt.Sprintf("https://www.circl.lu/pdns/query/%s", target)
+	req := &http.Request{URL: url, Header: map[string][]string{"Authorization": {apiKey}}}
+
+	resp, err := http.RequestWebPageThrottled(ctx, c.Name(), sessionID, req, http.DefaultPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	dec := json.NewDecoder(strings.NewReader(resp.Body))
+	for {
+		var entry struct {
+			RRName string `json:"rrname"`
+			RRType string `json:"rrtype"`
+			RData  string `json:"rdata"`
+		}
+		if err := dec.Decode(&entry); err != nil {
+			break
+		}
+		records = append(records, &Record{QName: entry.RRName, QType: entry.RRType, RData: entry.RData})
+	}
+	return records, nil
+}
+
+type otx struct{}
+
+func (o *otx) Name() string { return "otx" }
+
+func (o *otx) Query(ctx context.Context, apiKey, sessionID, target string) ([]*Record, error) {
+	url := fmt.Sprintf("https://otx.alienvault.com/api/v1/indicators/domain/%s/passive_dns", target)
+	req := &http.Request{URL: url, Header: map[string][]string{"X-OTX-API-KEY": {apiKey}}}
+
+	resp, err := http.RequestWebPageThrottled(ctx, o.Name(), sessionID, req, http.DefaultPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		PassiveDNS []struct {
+			Hostname   string `json:"hostname"`
+			RecordType string `json:"record_type"`
+			Address    string `json:"address"`
+			First      string `json:"first"`
+			Last       string `json:"last"`
+		} `json:"passive_dns"`
+	}
+	if err := json.Unmarshal([]byte(resp.Body), &result); err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	for _, d := range result.PassiveDNS {
+		first, _ := time.Parse(time.RFC3339, d.First)
+		last, _ := time.Parse(time.RFC3339, d.Last)
+		records = append(records, &Record{
+			QName:     d.Hostname,
+			QType:     d.RecordType,
+			RData:     d.Address,
+			FirstSeen: first,
+			LastSeen:  last,
+		})
+	}
+	return records, nil
+}
+
+type hackerTarget struct{}
+
+func (h *hackerTarget) Name() string { return "hackertarget" }
+
+func (h *hackerTarget) Query(ctx context.Context, apiKey, sessionID, target string) ([]*Record, error) {
+	url := fmt.Sprintf("https://api.hackertarget.com/hostsearch/?q=%s", target)
+
+	resp, err := http.RequestWebPageThrottled(ctx, h.Name(), sessionID, &http.Request{URL: url}, http.DefaultPolicy())
+	if err != nil {
+		return nil, err
+	}
+
+	var records []*Record
+	for _, line := range strings.Split(resp.Body, "\n") {
+		parts := strings.Split(strings.TrimSpace(line), ",")
+		if len(parts) != 2 {
+			continue
+		}
+		records = append(records, &Record{QName: parts[0], QType: "A", RData: parts[1]})
+	}
+	return records, nil
+}
@@ -5,7 +5,6 @@
 package scrape
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"strings"
@@ -19,10 +18,14 @@ import (
 
 type siteDossier struct {
 	fmtstr string
+	policy *http.Policy
 }
 
 func NewSiteDossier() et.Plugin {
-	return &siteDossier{fmtstr: "http://www.sitedossier.com/parentdomain/%s/%d"}
+	return &siteDossier{
+		fmtstr: "http://www.sitedossier.com/parentdomain/%s/%d",
+		policy: http.DefaultPolicy(),
+	}
 }
 
 func (sd *siteDossier) Start(r et.Registry) error {
@@ -62,17 +65,17 @@ func (sd *siteDossier) check(e *et.Event) error {
 	}
 
 	for i := 1; i < 20; i++ {
-		if body, err := sd.query(domlt, i); err == nil {
+		if body, err := sd.query(e, domlt, i); err == nil {
 			sd.process(e, body)
 		}
 	}
 	return nil
 }
 
-func (sd *siteDossier) query(name string, itemnum int) (string, error) {
+func (sd *siteDossier) query(e *et.Event, name string, itemnum int) (string, error) {
 	req := &http.Request{URL: fmt.Sprintf(sd.fmtstr, name, itemnum)}
 
-	resp, err := http.RequestWebPage(context.TODO(), req)
+	resp, err := http.RequestWebPageThrottled(e.Session.Ctx(), "sitedossier", e.Session.ID(), req, sd.policy)
 	if err != nil {
 		return "", fmt.Errorf("error fetching URL: %w", err)
 	}